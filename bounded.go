@@ -0,0 +1,62 @@
+package mmheap
+
+// Bounded wraps a min-max heap with a fixed capacity, keeping only the Cap
+// smallest elements ever pushed to it (or the Cap largest, if Largest is
+// set).
+//
+// A min-max heap is a natural fit for this: the smallest and largest
+// elements are both available in O(1), so deciding whether an incoming
+// element displaces the current worst one, and evicting that worst one,
+// are each O(log n). A plain min-heap (or max-heap) cannot do this without
+// maintaining a second structure to track the other extreme.
+type Bounded struct {
+	Interface
+	Cap     int
+	Largest bool
+}
+
+// NewBounded returns a Bounded wrapping h, which should be empty (or
+// already satisfy the min-max heap invariant via Init), retaining at most
+// cap elements. If largest is true, the cap largest elements pushed are
+// kept; otherwise, the cap smallest are kept.
+func NewBounded(h Interface, cap int, largest bool) *Bounded {
+	return &Bounded{Interface: h, Cap: cap, Largest: largest}
+}
+
+// Push adds x to the heap. If the heap was already at Cap elements, x is
+// inserted and then the current worst element (the maximum if b is keeping
+// the smallest elements, the minimum if b.Largest) is evicted, keeping the
+// heap at Cap elements.
+func (b *Bounded) Push(x interface{}) {
+	PushBounded(b.Interface, x, b.Cap, b.Largest)
+}
+
+// PushBounded pushes x onto h, which must satisfy the min-max heap
+// invariant and contain at most cap elements. If h is already at cap
+// elements, x is inserted and then the current worst element (the maximum
+// if largest is false, the minimum if largest is true) is evicted,
+// keeping h at cap elements.
+//
+// This is the free-function equivalent of Bounded.Push, for callers that
+// do not want to wrap their heap in a Bounded.
+func PushBounded(h Interface, x interface{}, cap int, largest bool) {
+	Push(h, x)
+	if h.Len() <= cap {
+		return
+	}
+	if largest {
+		Remove(h, 0)
+	} else {
+		Remove(h, Max(h))
+	}
+}
+
+// Drain pops every element off of h and returns them in ascending sorted
+// order. After Drain, h is empty.
+func Drain(h Interface) []interface{} {
+	out := make([]interface{}, 0, h.Len())
+	for h.Len() > 0 {
+		out = append(out, Pop(h))
+	}
+	return out
+}
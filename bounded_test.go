@@ -0,0 +1,191 @@
+package mmheap
+
+import (
+	"container/heap"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestBoundedSmallest(t *testing.T) {
+	const cap = 10
+	h := new(intHeap)
+	b := NewBounded(h, cap, false)
+
+	rng := rand.New(rand.NewSource(0))
+	const n = 1000
+	in := make([]int, n)
+	for i := range in {
+		in[i] = rng.Intn(n)
+	}
+	for _, x := range in {
+		b.Push(x)
+	}
+
+	exp := append([]int(nil), in...)
+	sort.Ints(exp)
+	exp = exp[:cap]
+
+	got := Drain(h)
+	if len(got) != len(exp) {
+		t.Fatalf("got %d elements, want %d", len(got), len(exp))
+	}
+	for i, g := range got {
+		if g.(int) != exp[i] {
+			t.Errorf("got[%d] = %d, want %d", i, g, exp[i])
+		}
+	}
+}
+
+func TestBoundedLargest(t *testing.T) {
+	const cap = 10
+	h := new(intHeap)
+	b := NewBounded(h, cap, true)
+
+	rng := rand.New(rand.NewSource(1))
+	const n = 1000
+	in := make([]int, n)
+	for i := range in {
+		in[i] = rng.Intn(n)
+	}
+	for _, x := range in {
+		b.Push(x)
+	}
+
+	exp := append([]int(nil), in...)
+	sort.Sort(sort.Reverse(sort.IntSlice(exp)))
+	exp = exp[:cap]
+	sort.Ints(exp)
+
+	got := Drain(h)
+	if len(got) != len(exp) {
+		t.Fatalf("got %d elements, want %d", len(got), len(exp))
+	}
+	for i, g := range got {
+		if g.(int) != exp[i] {
+			t.Errorf("got[%d] = %d, want %d", i, g, exp[i])
+		}
+	}
+}
+
+// verifyMinMax checks the full min-max heap invariant at and below i: every
+// min-level node must be <= not just its children but all its descendants,
+// and every max-level node must be >= all its descendants. intHeap's own
+// verify only compares a node against its immediate children, which a
+// same-dimension violation two levels down (a bad grandchild) would not
+// catch.
+func verifyMinMax(t *testing.T, h intHeap, i int) {
+	t.Helper()
+	n := len(h)
+	minLevel := isMinLevel(i)
+
+	check := func(j int, relation string) {
+		if j >= n {
+			return
+		}
+		if minLevel && h[j] < h[i] {
+			t.Errorf("min-max invariant invalidated: [%d] = %d > %s [%d] = %d", i, h[i], relation, j, h[j])
+		}
+		if !minLevel && h[j] > h[i] {
+			t.Errorf("min-max invariant invalidated: [%d] = %d < %s [%d] = %d", i, h[i], relation, j, h[j])
+		}
+	}
+
+	l, r := 2*i+1, 2*i+2
+	check(l, "child")
+	check(r, "child")
+	check(2*l+1, "grandchild")
+	check(2*l+2, "grandchild")
+	check(2*r+1, "grandchild")
+	check(2*r+2, "grandchild")
+
+	if l < n {
+		verifyMinMax(t, h, l)
+	}
+	if r < n {
+		verifyMinMax(t, h, r)
+	}
+}
+
+func TestBoundedInvariant(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	const cap = 16
+	const n = 500
+
+	for _, largest := range []bool{false, true} {
+		h := new(intHeap)
+		b := NewBounded(h, cap, largest)
+		for i := 0; i < n; i++ {
+			b.Push(rng.Intn(n))
+			if h.Len() >= cap {
+				verifyMinMax(t, *h, 0)
+			}
+		}
+	}
+}
+
+// container/heap-based top-K smallest, for benchmark comparison against
+// Bounded: a max-heap of size K, evicting the max when a smaller element
+// arrives.
+type maxIntHeap []int
+
+func (h maxIntHeap) Len() int            { return len(h) }
+func (h maxIntHeap) Less(i, j int) bool  { return h[i] > h[j] }
+func (h maxIntHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *maxIntHeap) Push(x interface{}) { *h = append(*h, x.(int)) }
+func (h *maxIntHeap) Pop() (v interface{}) {
+	old := *h
+	n := len(old)
+	v = old[n-1]
+	*h = old[:n-1]
+	return
+}
+
+func topKContainerHeap(xs []int, k int) *maxIntHeap {
+	h := new(maxIntHeap)
+	for _, x := range xs {
+		if h.Len() < k {
+			heap.Push(h, x)
+			continue
+		}
+		if x < (*h)[0] {
+			(*h)[0] = x
+			heap.Fix(h, 0)
+		}
+	}
+	return h
+}
+
+func BenchmarkTopKBounded(b *testing.B) {
+	const n = 10000
+	const k = 100
+	rng := rand.New(rand.NewSource(0))
+	xs := make([]int, n)
+	for i := range xs {
+		xs[i] = rng.Intn(n)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h := new(intHeap)
+		bnd := NewBounded(h, k, false)
+		for _, x := range xs {
+			bnd.Push(x)
+		}
+	}
+}
+
+func BenchmarkTopKContainerHeap(b *testing.B) {
+	const n = 10000
+	const k = 100
+	rng := rand.New(rand.NewSource(0))
+	xs := make([]int, n)
+	for i := range xs {
+		xs[i] = rng.Intn(n)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		topKContainerHeap(xs, k)
+	}
+}
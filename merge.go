@@ -0,0 +1,26 @@
+package mmheap
+
+// Merge consumes src into dst, leaving src empty.
+//
+// This is more efficient than the naive
+// for src.Len() > 0 { Push(dst, Pop(src)) }, which costs
+// O(m log(n+m)) for dst's n existing elements and src's m: instead, every
+// element of src is appended onto dst via dst.Push, and a single
+// Floyd-style bottom-up heapify (the same one Init performs) restores the
+// invariant in O(n+m).
+func Merge(dst, src Interface) {
+	for src.Len() > 0 {
+		dst.Push(src.Pop())
+	}
+	Init(dst)
+}
+
+// MergeSlice merges xs into h, for the common case of bulk-loading a raw
+// slice rather than another heap. It is equivalent to pushing every element
+// of xs onto h followed by a single Init, done in one pass.
+func MergeSlice(h Interface, xs []interface{}) {
+	for _, x := range xs {
+		h.Push(x)
+	}
+	Init(h)
+}
@@ -0,0 +1,119 @@
+package mmheap
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+
+	dst := new(intHeap)
+	for i := 0; i < 50; i++ {
+		Push(dst, rng.Intn(1000))
+	}
+
+	src := new(intHeap)
+	for i := 0; i < 30; i++ {
+		Push(src, rng.Intn(1000))
+	}
+
+	want := append(append([]int(nil), *dst...), *src...)
+
+	Merge(dst, src)
+	dst.verify(t, 0)
+
+	if src.Len() != 0 {
+		t.Errorf("src.Len() = %d; want 0", src.Len())
+	}
+	if dst.Len() != len(want) {
+		t.Fatalf("dst.Len() = %d; want %d", dst.Len(), len(want))
+	}
+
+	got := make([]int, 0, len(want))
+	for dst.Len() > 0 {
+		got = append(got, Pop(dst).(int))
+	}
+
+	counts := make(map[int]int, len(want))
+	for _, v := range want {
+		counts[v]++
+	}
+	for _, v := range got {
+		counts[v]--
+	}
+	for v, c := range counts {
+		if c != 0 {
+			t.Errorf("count mismatch for %d: off by %d", v, c)
+		}
+	}
+}
+
+func TestMergeSlice(t *testing.T) {
+	h := new(intHeap)
+	for i := 0; i < 10; i++ {
+		Push(h, i)
+	}
+
+	MergeSlice(h, []interface{}{9, 7, 100, -5})
+	h.verify(t, 0)
+
+	if h.Len() != 14 {
+		t.Fatalf("Len() = %d; want 14", h.Len())
+	}
+	if got := Pop(h).(int); got != -5 {
+		t.Errorf("first pop = %d; want -5", got)
+	}
+}
+
+func BenchmarkMerge(b *testing.B) {
+	const n, m = 5000, 5000
+	rng := rand.New(rand.NewSource(0))
+
+	srcVals := make([]int, m)
+	for i := range srcVals {
+		srcVals[i] = rng.Intn(n + m)
+	}
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		dst := new(intHeap)
+		for j := 0; j < n; j++ {
+			Push(dst, rng.Intn(n+m))
+		}
+		src := new(intHeap)
+		for _, v := range srcVals {
+			Push(src, v)
+		}
+		b.StartTimer()
+
+		Merge(dst, src)
+	}
+}
+
+func BenchmarkMergeNaive(b *testing.B) {
+	const n, m = 5000, 5000
+	rng := rand.New(rand.NewSource(0))
+
+	srcVals := make([]int, m)
+	for i := range srcVals {
+		srcVals[i] = rng.Intn(n + m)
+	}
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		dst := new(intHeap)
+		for j := 0; j < n; j++ {
+			Push(dst, rng.Intn(n+m))
+		}
+		src := new(intHeap)
+		for _, v := range srcVals {
+			Push(src, v)
+		}
+		b.StartTimer()
+
+		for src.Len() > 0 {
+			Push(dst, Pop(src))
+		}
+	}
+}
@@ -50,7 +50,7 @@ func Push(h Interface, x interface{}) {
 func Pop(h Interface) interface{} {
 	n := h.Len() - 1
 	h.Swap(0, n)
-	down(h, 0, n)
+	up(h, down(h, 0, n))
 	return h.Pop()
 }
 
@@ -58,17 +58,23 @@ func Remove(h Interface, i int) interface{} {
 	n := h.Len() - 1
 	if n != i {
 		h.Swap(i, n)
-		if !down(h, i, n) {
-			up(h, i)
-		}
+		up(h, down(h, i, n))
 	}
 	return h.Pop()
 }
 
+// Fix re-establishes the heap ordering after the element at index i has
+// changed its value.
+//
+// down alone is not sufficient here the way it is in a plain binary heap:
+// down only ever follows the dimension (min or max) that i started on, so
+// if the new value at i needs to cross into the other dimension (e.g. a
+// decreased max-level value sinking into a min-level descendant), the
+// landing spot may still be out of order relative to its own same-dimension
+// ancestors. up is always run afterward, from the index down reports the
+// element actually landed at, to chase that the rest of the way.
 func Fix(h Interface, i int) {
-	if !down(h, i, h.Len()) {
-		up(h, i)
-	}
+	up(h, down(h, i, h.Len()))
 }
 
 // Max returns the index of the maximum element of the heap.
@@ -150,9 +156,25 @@ func grandparent(index int) int {
 	return parent(parent(index))
 }
 
-func down(h Interface, i0, n int) bool {
+// down sifts the element at i0 down the heap and returns the index it ends
+// up at. Callers that need the heap ordering fully restored (rather than
+// just the same-dimension descendant invariant down alone maintains) must
+// follow up with up(h, down(h, i0, n)); see Fix.
+//
+// The element originally at i0 doesn't necessarily end up at on, the loop's
+// own position variable: when a grandchild swap's trailing parent-fixup
+// fires (the `h.Swap(smallest.index, p)` below), it's that fixup, not the
+// grandchild swap before it, that places the i0 element into its final
+// resting spot at p, while on goes on to track a different, unrelated
+// element through the rest of the loop (the grandchild's parent's old
+// value, now continuing its own descent from the grandchild's position).
+// landed tracks the i0 element specifically, and freezes the first time
+// that happens, since the element is never moved again afterward.
+func down(h Interface, i0, n int) int {
 	on := i0
 	onMinLevel := isMinLevel(i0)
+	landed := i0
+	tracking := true
 
 	for {
 		l := 2*on + 1
@@ -197,6 +219,9 @@ func down(h Interface, i0, n int) bool {
 			break
 		}
 		h.Swap(on, smallest.index)
+		if tracking {
+			landed = smallest.index
+		}
 		on = smallest.index
 		if smallest.relation == child {
 			break
@@ -204,7 +229,11 @@ func down(h Interface, i0, n int) bool {
 		p := parent(smallest.index)
 		if onMinLevel == h.Less(p, smallest.index) {
 			h.Swap(smallest.index, p)
+			if tracking {
+				landed = p
+				tracking = false
+			}
 		}
 	}
-	return on > i0
+	return landed
 }
@@ -172,7 +172,7 @@ func TestRemove0(t *testing.T) {
 	h.verify(t, 0)
 
 	for h.Len() > 0 {
-		i := MaxIndex(h)
+		i := Max(h)
 		exp := h.Len() - 1
 		x := Remove(h, i).(int)
 		if x != exp {
@@ -253,6 +253,61 @@ func TestFix(t *testing.T) {
 	}
 }
 
+// TestFixCrossLevel guards against a Fix bug where a decrease at a
+// max-level index sinks into a min-level descendant (a single-level,
+// cross-dimension move) without then sifting up the rest of the way along
+// that new dimension, leaving a smaller value buried below the root.
+func TestFixCrossLevel(t *testing.T) {
+	h := &intHeap{1, 1, 1, 1}
+	Init(h)
+
+	(*h)[1] = 0
+	Fix(h, 1)
+	h.verify(t, 0)
+
+	var got []int
+	for h.Len() > 0 {
+		got = append(got, Pop(h).(int))
+	}
+	want := []int{0, 1, 1, 1}
+	for i, v := range want {
+		if got[i] != v {
+			t.Fatalf("got %v; want %v", got, want)
+		}
+	}
+}
+
+// TestFixRemoveInvariant stress-tests Fix and Remove at arbitrary interior
+// indices, checking the full min-max invariant (not just against immediate
+// children, which h.verify does) after every operation. This is the shape
+// that catches a sifted element getting stranded partway down the heap when
+// a multi-level, cross-dimension descent's intermediate-parent fixup swap
+// fires: see verifyMinMax in bounded_test.go.
+func TestFixRemoveInvariant(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	const n = 200
+
+	h := make(intHeap, n)
+	for i := range h {
+		h[i] = rng.Intn(n)
+	}
+	Init(&h)
+
+	for i := 0; i < 20*n; i++ {
+		if h.Len() == 0 {
+			Push(&h, rng.Intn(n))
+		}
+		if rng.Intn(4) == 0 {
+			Remove(&h, rng.Intn(h.Len()))
+		} else {
+			idx := rng.Intn(h.Len())
+			h[idx] = rng.Intn(n)
+			Fix(&h, idx)
+		}
+		verifyMinMax(t, h, 0)
+	}
+}
+
 func BenchmarkDup(b *testing.B) {
 	const n = 10000
 	h := make(intHeap, 0, n)
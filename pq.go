@@ -0,0 +1,87 @@
+package mmheap
+
+// PopMin removes and returns the minimum element of h. It is equivalent to
+// Pop; it exists to pair with PopMax for callers using mmheap as a
+// double-ended priority queue.
+func PopMin(h Interface) interface{} {
+	return Pop(h)
+}
+
+// PopMax removes and returns the maximum element of h.
+func PopMax(h Interface) interface{} {
+	return Remove(h, Max(h))
+}
+
+// PeekMin returns the minimum element of h without removing it.
+//
+// Interface has no way to read an element without popping it, so this pops
+// and immediately pushes the element back; prefer PriorityQueue (or your
+// own Interface with direct slice access) if you need O(1) peeks.
+func PeekMin(h Interface) interface{} {
+	x := Pop(h)
+	Push(h, x)
+	return x
+}
+
+// PeekMax returns the maximum element of h without removing it.
+//
+// As with PeekMin, this costs a Remove and a Push because Interface has no
+// way to read an element without popping it.
+func PeekMax(h Interface) interface{} {
+	x := PopMax(h)
+	Push(h, x)
+	return x
+}
+
+// Item is an element of a PriorityQueue, modeled on container/heap's
+// example_pq_test.go Item type. Value is caller data; Priority orders the
+// queue, with lower priorities popped first by PopMin and higher priorities
+// by PopMax.
+type Item struct {
+	Value    interface{}
+	Priority int
+
+	index int // maintained by PriorityQueue.Swap
+}
+
+// PriorityQueue implements Interface over a slice of *Item, making mmheap a
+// double-ended priority queue: PopMin and PopMax give O(log n) access to
+// the lowest- and highest-priority items, and Update lets an item's
+// priority change in place without a linear search for its index.
+type PriorityQueue []*Item
+
+func (pq PriorityQueue) Len() int { return len(pq) }
+
+func (pq PriorityQueue) Less(i, j int) bool {
+	return pq[i].Priority < pq[j].Priority
+}
+
+func (pq PriorityQueue) Swap(i, j int) {
+	pq[i], pq[j] = pq[j], pq[i]
+	pq[i].index = i
+	pq[j].index = j
+}
+
+func (pq *PriorityQueue) Push(x interface{}) {
+	item := x.(*Item)
+	item.index = len(*pq)
+	*pq = append(*pq, item)
+}
+
+func (pq *PriorityQueue) Pop() interface{} {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*pq = old[:n-1]
+	return item
+}
+
+// Update changes item's priority and restores the heap invariant, using
+// item's own index to call Fix directly instead of searching the queue for
+// it.
+func (pq *PriorityQueue) Update(item *Item, priority int) {
+	item.Priority = priority
+	Fix(pq, item.index)
+}
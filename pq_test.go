@@ -0,0 +1,106 @@
+package mmheap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// verifyPQMinMax checks the full min-max heap invariant at and below i by
+// Priority: every min-level node must be <= not just its children but all
+// its descendants, and every max-level node must be >= all its descendants.
+// See verifyMinMax in bounded_test.go, which this mirrors for PriorityQueue.
+func verifyPQMinMax(t *testing.T, pq PriorityQueue, i int) {
+	t.Helper()
+	n := len(pq)
+	minLevel := isMinLevel(i)
+
+	check := func(j int, relation string) {
+		if j >= n {
+			return
+		}
+		if minLevel && pq[j].Priority < pq[i].Priority {
+			t.Errorf("min-max invariant invalidated: [%d] = %d > %s [%d] = %d", i, pq[i].Priority, relation, j, pq[j].Priority)
+		}
+		if !minLevel && pq[j].Priority > pq[i].Priority {
+			t.Errorf("min-max invariant invalidated: [%d] = %d < %s [%d] = %d", i, pq[i].Priority, relation, j, pq[j].Priority)
+		}
+	}
+
+	l, r := 2*i+1, 2*i+2
+	check(l, "child")
+	check(r, "child")
+	check(2*l+1, "grandchild")
+	check(2*l+2, "grandchild")
+	check(2*r+1, "grandchild")
+	check(2*r+2, "grandchild")
+
+	if l < n {
+		verifyPQMinMax(t, pq, l)
+	}
+	if r < n {
+		verifyPQMinMax(t, pq, r)
+	}
+}
+
+func TestPriorityQueueUpdate(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	const n = 200
+
+	pq := make(PriorityQueue, n)
+	for i := range pq {
+		pq[i] = &Item{Value: i, Priority: rng.Intn(n), index: i}
+	}
+	Init(&pq)
+
+	// Repeatedly set random items to arbitrary new priorities, checking the
+	// full invariant after every single Update, not just the final drained
+	// order: an update that strands an element partway down the heap can
+	// still happen to drain in sorted order if nothing else touches that
+	// region afterward, so only a per-step check reliably catches it.
+	for i := 0; i < 10*n; i++ {
+		item := pq[rng.Intn(pq.Len())]
+		pq.Update(item, rng.Intn(n))
+		verifyPQMinMax(t, pq, 0)
+	}
+
+	want := make([]int, n)
+	for i, item := range pq {
+		want[i] = item.Priority
+	}
+	sort.Ints(want)
+
+	got := make([]int, 0, n)
+	for pq.Len() > 0 {
+		got = append(got, PopMin(&pq).(*Item).Priority)
+	}
+
+	for i, p := range got {
+		if p != want[i] {
+			t.Fatalf("popped[%d] = %d; want %d (full: %v)", i, p, want[i], got)
+		}
+	}
+}
+
+func TestPopMinMaxPeek(t *testing.T) {
+	h := new(intHeap)
+	for _, v := range []int{5, 3, 8, 1, 9, 2} {
+		Push(h, v)
+	}
+
+	if got := PeekMin(h).(int); got != 1 {
+		t.Errorf("PeekMin = %d; want 1", got)
+	}
+	if got := PeekMax(h).(int); got != 9 {
+		t.Errorf("PeekMax = %d; want 9", got)
+	}
+	if got := PopMax(h).(int); got != 9 {
+		t.Errorf("PopMax = %d; want 9", got)
+	}
+	if got := PopMin(h).(int); got != 1 {
+		t.Errorf("PopMin = %d; want 1", got)
+	}
+	if h.Len() != 4 {
+		t.Errorf("Len = %d; want 4", h.Len())
+	}
+}
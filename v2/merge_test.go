@@ -0,0 +1,69 @@
+package mmheap
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+
+	dst := newIntHeap()
+	for i := 0; i < 50; i++ {
+		Push(dst.Heap(), rng.Intn(1000))
+	}
+
+	src := newIntHeap()
+	for i := 0; i < 30; i++ {
+		Push(src.Heap(), rng.Intn(1000))
+	}
+
+	want := append(append([]int(nil), dst.S...), src.S...)
+
+	Merge(dst.Heap(), src.Heap())
+	verify(t, dst, 0)
+
+	if len(src.S) != 0 {
+		t.Errorf("len(src.S) = %d; want 0", len(src.S))
+	}
+	if len(dst.S) != len(want) {
+		t.Fatalf("len(dst.S) = %d; want %d", len(dst.S), len(want))
+	}
+
+	h := dst.Heap()
+	got := make([]int, 0, len(want))
+	for h.Len() > 0 {
+		got = append(got, Pop(h))
+	}
+
+	counts := make(map[int]int, len(want))
+	for _, v := range want {
+		counts[v]++
+	}
+	for _, v := range got {
+		counts[v]--
+	}
+	for v, c := range counts {
+		if c != 0 {
+			t.Errorf("count mismatch for %d: off by %d", v, c)
+		}
+	}
+}
+
+func TestMergeSlice(t *testing.T) {
+	s := newIntHeap()
+	h := s.Heap()
+	for i := 0; i < 10; i++ {
+		Push(h, i)
+	}
+
+	MergeSlice(h, []int{9, 7, 100, -5})
+	verify(t, s, 0)
+
+	if h.Len() != 14 {
+		t.Fatalf("Len() = %d; want 14", h.Len())
+	}
+	if got := Pop(h); got != -5 {
+		t.Errorf("first pop = %d; want -5", got)
+	}
+}
@@ -0,0 +1,261 @@
+// Package mmheap provides a drop-in min-max heap for any type that
+// implements Interface[T], the generic analog of container/heap.Interface.
+//
+// This is a generic port of github.com/twmb/go-mmheap. Callers that do not
+// need to support arbitrary element types benefit from avoiding the
+// interface{} boxing that the v1 package requires on every Push and Pop; see
+// the package benchmarks for the difference this makes.
+//
+// See the v1 package (github.com/twmb/go-mmheap) for a full description of
+// min-max heaps and why you might want one.
+package mmheap
+
+import "math/bits"
+
+// Interface is the generic analog of container/heap.Interface: any type
+// implementing these five methods can be organized as a min-max heap.
+type Interface[T any] interface {
+	Len() int
+	Less(i, j int) bool
+	Swap(i, j int)
+	Push(x T)
+	Pop() T
+}
+
+// Slice is a ready-to-use Interface[T] backed by a slice and a Less
+// function, for callers who just want to heapify a []T by a comparison
+// function and do not need to define their own type.
+//
+// Slice cannot implement Interface[T] directly, because Interface requires
+// a Less(i, j int) bool method and Slice already has a Less field of a
+// different signature. Use Heap to obtain an Interface[T] backed by a
+// Slice.
+type Slice[T any] struct {
+	S    []T
+	Less func(a, b T) bool
+}
+
+// Heap returns an Interface[T] that operates on s, suitable for passing to
+// Init, Push, Pop, Remove, and Fix.
+func (s *Slice[T]) Heap() Interface[T] {
+	return sliceHeap[T]{s}
+}
+
+type sliceHeap[T any] struct {
+	s *Slice[T]
+}
+
+func (h sliceHeap[T]) Len() int           { return len(h.s.S) }
+func (h sliceHeap[T]) Less(i, j int) bool { return h.s.Less(h.s.S[i], h.s.S[j]) }
+func (h sliceHeap[T]) Swap(i, j int)      { h.s.S[i], h.s.S[j] = h.s.S[j], h.s.S[i] }
+func (h sliceHeap[T]) Push(x T)           { h.s.S = append(h.s.S, x) }
+
+func (h sliceHeap[T]) Pop() T {
+	n := len(h.s.S) - 1
+	x := h.s.S[n]
+	h.s.S = h.s.S[:n]
+	return x
+}
+
+func Init[T any](h Interface[T]) {
+	n := h.Len()
+	for i := n/2 - 1; i >= 0; i-- {
+		down(h, i, n)
+	}
+}
+
+func Push[T any](h Interface[T], x T) {
+	h.Push(x)
+	up(h, h.Len()-1)
+}
+
+func Pop[T any](h Interface[T]) T {
+	n := h.Len() - 1
+	h.Swap(0, n)
+	up(h, down(h, 0, n))
+	return h.Pop()
+}
+
+func Remove[T any](h Interface[T], i int) T {
+	n := h.Len() - 1
+	if n != i {
+		h.Swap(i, n)
+		up(h, down(h, i, n))
+	}
+	return h.Pop()
+}
+
+// Fix re-establishes the heap ordering after the element at index i has
+// changed its value.
+//
+// down alone is not sufficient here the way it is in a plain binary heap:
+// down only ever follows the dimension (min or max) that i started on, so
+// if the new value at i needs to cross into the other dimension (e.g. a
+// decreased max-level value sinking into a min-level descendant), the
+// landing spot may still be out of order relative to its own same-dimension
+// ancestors. up is always run afterward, from the index down reports the
+// element actually landed at, to chase that the rest of the way.
+func Fix[T any](h Interface[T], i int) {
+	up(h, down(h, i, h.Len()))
+}
+
+// Max returns the index of the maximum element of the heap.
+//
+// This is a convenience function that always returns either 0, 1, or 2.
+// This will panic if the heap has no elements.
+func Max[T any](h Interface[T]) int {
+	switch h.Len() {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	default:
+		if h.Less(1, 2) {
+			return 2
+		}
+		return 1
+	}
+}
+
+func up[T any](h Interface[T], on int) {
+	onMinLevel := isMinLevel(on)
+
+	// On min level:
+	// If we have a parent, if our parent is less than us, then we swap
+	// with the parent. Our parent should not be less than us.
+	//
+	// On max level:
+	// If we have a parent, if our parent is more than us, then we swap
+	// with the parent. Our parent should not be more than us.
+	//
+	// If we swap, our level changed by one, and we need to swap onMinLevel.
+	parent := parent(on)
+	if hasParent(on) {
+		if onMinLevel == h.Less(parent, on) {
+			h.Swap(on, parent)
+			on = parent
+			onMinLevel = !onMinLevel
+		}
+	}
+
+	// On min level:
+	// While we have a grandparent, if our grandparent is less than us,
+	// then we swap with our grandparent.
+	//
+	// On max level:
+	// While we have a grandparent, if our grandparent is more than us,
+	// same.
+	for hasGrandparent(on) {
+		grandparent := grandparent(on)
+		if onMinLevel == h.Less(on, grandparent) {
+			h.Swap(on, grandparent)
+			on = grandparent
+			continue
+		}
+		break
+	}
+}
+
+// min levels are odd levels, following a log pattern, so the odd expression
+// below works out.
+func isMinLevel(index int) bool {
+	return bits.LeadingZeros(uint(index+1))&1 == 1
+}
+
+func hasParent(index int) bool {
+	return index > 0
+}
+
+func parent(index int) int {
+	return (index - 1) / 2
+}
+
+func hasGrandparent(index int) bool {
+	return index > 2
+}
+
+func grandparent(index int) int {
+	return parent(parent(index))
+}
+
+// down sifts the element at i0 down the heap and returns the index it ends
+// up at. Callers that need the heap ordering fully restored (rather than
+// just the same-dimension descendant invariant down alone maintains) must
+// follow up with up(h, down(h, i0, n)); see Fix.
+//
+// The element originally at i0 doesn't necessarily end up at on, the loop's
+// own position variable: when a grandchild swap's trailing parent-fixup
+// fires (the `h.Swap(smallest.index, p)` below), it's that fixup, not the
+// grandchild swap before it, that places the i0 element into its final
+// resting spot at p, while on goes on to track a different, unrelated
+// element through the rest of the loop (the grandchild's parent's old
+// value, now continuing its own descent from the grandchild's position).
+// landed tracks the i0 element specifically, and freezes the first time
+// that happens, since the element is never moved again afterward.
+func down[T any](h Interface[T], i0, n int) int {
+	on := i0
+	onMinLevel := isMinLevel(i0)
+	landed := i0
+	tracking := true
+
+	for {
+		l := 2*on + 1
+		r := l + 1
+
+		ll := 2*l + 1
+		lr := ll + 1
+
+		rl := 2*r + 1
+		rr := rl + 1
+
+		type relation uint8
+		const (
+			self relation = iota
+			child
+			grandchild
+		)
+
+		type progeny struct {
+			index    int
+			relation relation
+		}
+
+		smallest := progeny{on, self}
+		for _, progeny := range &[...]progeny{
+			{l, child},
+			{r, child},
+			{ll, grandchild},
+			{lr, grandchild},
+			{rl, grandchild},
+			{rr, grandchild},
+		} {
+			if progeny.index >= n || progeny.index < 0 {
+				break
+			}
+			if onMinLevel == h.Less(progeny.index, smallest.index) {
+				smallest = progeny
+			}
+		}
+
+		if smallest.relation == self {
+			break
+		}
+		h.Swap(on, smallest.index)
+		if tracking {
+			landed = smallest.index
+		}
+		on = smallest.index
+		if smallest.relation == child {
+			break
+		}
+		p := parent(smallest.index)
+		if onMinLevel == h.Less(p, smallest.index) {
+			h.Swap(smallest.index, p)
+			if tracking {
+				landed = p
+				tracking = false
+			}
+		}
+	}
+	return landed
+}
@@ -0,0 +1,394 @@
+package mmheap
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// The tests below are ripped straight from stdlib's container/heap
+// with minor modifications where necessary, mirroring the v1 package's
+// tests against the generic Interface[T] and Slice[T] types.
+//
+// All benchmarks but BenchmarkDup are new, and are duplicated from v1 to
+// demonstrate the allocation/perf win of avoiding interface{} boxing.
+
+/*
+Copyright (c) 2009 The Go Authors. All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are
+met:
+
+   * Redistributions of source code must retain the above copyright
+notice, this list of conditions and the following disclaimer.
+   * Redistributions in binary form must reproduce the above
+copyright notice, this list of conditions and the following disclaimer
+in the documentation and/or other materials provided with the
+distribution.
+   * Neither the name of Google Inc. nor the names of its
+contributors may be used to endorse or promote products derived from
+this software without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+"AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR
+A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT
+LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE,
+DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY
+THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+(INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+*/
+
+func newIntHeap() *Slice[int] {
+	return &Slice[int]{Less: func(a, b int) bool { return a < b }}
+}
+
+func verify(t *testing.T, h *Slice[int], i int) {
+	t.Helper()
+	n := len(h.S)
+	j1 := 2*i + 1
+	j2 := 2*i + 2
+	badDirection := ">"
+	if !isMinLevel(i) {
+		badDirection = "<"
+	}
+	if j1 < n {
+		if isMinLevel(i) == h.Less(h.S[j1], h.S[i]) && h.S[j1] != h.S[i] {
+			t.Errorf("heap invariant invalidated [%d] = %d %s [%d] = %d",
+				i, h.S[i],
+				badDirection,
+				j1, h.S[j1],
+			)
+			return
+		}
+		verify(t, h, j1)
+	}
+	if j2 < n {
+		if isMinLevel(i) == h.Less(h.S[j2], h.S[i]) && h.S[j2] != h.S[i] {
+			t.Errorf("heap invariant invalidated [%d] = %d %s [%d] = %d",
+				i, h.S[i],
+				badDirection,
+				j2, h.S[j2],
+			)
+			return
+		}
+		verify(t, h, j2)
+	}
+}
+
+func TestInit0(t *testing.T) {
+	s := newIntHeap()
+	h := s.Heap()
+	for i := 20; i > 0; i-- {
+		h.Push(0) // all elements are the same
+	}
+	Init(h)
+	verify(t, s, 0)
+
+	for i := 1; h.Len() > 0; i++ {
+		x := Pop(h)
+		verify(t, s, 0)
+		if x != 0 {
+			t.Errorf("%d.th pop got %d; want %d", i, x, 0)
+		}
+	}
+}
+
+func TestInit1(t *testing.T) {
+	s := newIntHeap()
+	h := s.Heap()
+	for i := 20; i > 0; i-- {
+		h.Push(i) // all elements are different
+	}
+	Init(h)
+	verify(t, s, 0)
+
+	for i := 1; h.Len() > 0; i++ {
+		x := Pop(h)
+		verify(t, s, 0)
+		if x != i {
+			t.Errorf("%d.th pop got %d; want %d", i, x, i)
+		}
+	}
+}
+
+func Test(t *testing.T) {
+	s := newIntHeap()
+	h := s.Heap()
+	verify(t, s, 0)
+
+	for i := 20; i > 10; i-- {
+		h.Push(i)
+	}
+	Init(h)
+	verify(t, s, 0)
+
+	for i := 10; i > 0; i-- {
+		Push(h, i)
+		verify(t, s, 0)
+	}
+
+	for i := 1; h.Len() > 0; i++ {
+		x := Pop(h)
+		if i < 20 {
+			Push(h, 20+i)
+		}
+		verify(t, s, 0)
+		if x != i {
+			t.Errorf("%d.th pop got %d; want %d", i, x, i)
+		}
+	}
+}
+
+// The Go container/heap Remove tests relied on left to right heap ordering and
+// did not initialize the order of the heap.
+//
+// We init the heap after pushing the first 10 elements as well as do a little
+// bit more to determine the max (which was always removed in Remove0).
+
+func TestRemove0(t *testing.T) {
+	s := newIntHeap()
+	h := s.Heap()
+	for i := 0; i < 10; i++ {
+		h.Push(i)
+	}
+	Init(h)
+	verify(t, s, 0)
+
+	for h.Len() > 0 {
+		i := Max(h)
+		exp := h.Len() - 1
+		x := Remove(h, i)
+		if x != exp {
+			t.Errorf("Remove(%d) got %d; want %d", i, x, exp)
+		}
+		verify(t, s, 0)
+	}
+}
+
+func TestRemove1(t *testing.T) {
+	s := newIntHeap()
+	h := s.Heap()
+	for i := 0; i < 10; i++ {
+		h.Push(i)
+	}
+	Init(h)
+	verify(t, s, 0)
+
+	for i := 0; h.Len() > 0; i++ {
+		x := Remove(h, 0)
+		if x != i {
+			t.Errorf("Remove(0) got %d; want %d", x, i)
+		}
+		verify(t, s, 0)
+	}
+}
+
+func TestRemove2(t *testing.T) {
+	N := 10
+
+	s := newIntHeap()
+	h := s.Heap()
+	for i := 0; i < N; i++ {
+		h.Push(i)
+	}
+	Init(h)
+	verify(t, s, 0)
+
+	m := make(map[int]bool)
+	for h.Len() > 0 {
+		m[Remove(h, (h.Len()-1)/2)] = true
+		verify(t, s, 0)
+	}
+
+	if len(m) != N {
+		t.Errorf("len(m) = %d; want %d", len(m), N)
+	}
+	for i := 0; i < len(m); i++ {
+		if !m[i] {
+			t.Errorf("m[%d] doesn't exist", i)
+		}
+	}
+}
+
+func TestFix(t *testing.T) {
+	s := newIntHeap()
+	h := s.Heap()
+	verify(t, s, 0)
+
+	for i := 200; i > 0; i -= 10 {
+		Push(h, i)
+	}
+	verify(t, s, 0)
+
+	if s.S[0] != 10 {
+		t.Fatalf("Expected head to be 10, was %d", s.S[0])
+	}
+	s.S[0] = 210
+	Fix(h, 0)
+	verify(t, s, 0)
+
+	for i := 100; i > 0; i-- {
+		elem := rand.Intn(h.Len())
+		if i&1 == 0 {
+			s.S[elem] *= 2
+		} else {
+			s.S[elem] /= 2
+		}
+		Fix(h, elem)
+		verify(t, s, 0)
+	}
+}
+
+// TestFixCrossLevel guards against a Fix bug where a decrease at a
+// max-level index sinks into a min-level descendant (a single-level,
+// cross-dimension move) without then sifting up the rest of the way along
+// that new dimension, leaving a smaller value buried below the root.
+func TestFixCrossLevel(t *testing.T) {
+	s := &Slice[int]{S: []int{1, 1, 1, 1}, Less: func(a, b int) bool { return a < b }}
+	h := s.Heap()
+	Init(h)
+
+	s.S[1] = 0
+	Fix(h, 1)
+	verify(t, s, 0)
+
+	var got []int
+	for h.Len() > 0 {
+		got = append(got, Pop(h))
+	}
+	want := []int{0, 1, 1, 1}
+	for i, v := range want {
+		if got[i] != v {
+			t.Fatalf("got %v; want %v", got, want)
+		}
+	}
+}
+
+// verifyFull checks the full min-max heap invariant at and below i: every
+// min-level node must be <= not just its children but all its descendants,
+// and every max-level node must be >= all its descendants. verify only
+// compares a node against its immediate children, which a same-dimension
+// violation two levels down (a bad grandchild) would not catch.
+func verifyFull(t *testing.T, s *Slice[int], i int) {
+	t.Helper()
+	n := len(s.S)
+	minLevel := isMinLevel(i)
+
+	check := func(j int, relation string) {
+		if j >= n {
+			return
+		}
+		if minLevel && s.Less(s.S[j], s.S[i]) {
+			t.Errorf("min-max invariant invalidated: [%d] = %d > %s [%d] = %d", i, s.S[i], relation, j, s.S[j])
+		}
+		if !minLevel && s.Less(s.S[i], s.S[j]) {
+			t.Errorf("min-max invariant invalidated: [%d] = %d < %s [%d] = %d", i, s.S[i], relation, j, s.S[j])
+		}
+	}
+
+	l, r := 2*i+1, 2*i+2
+	check(l, "child")
+	check(r, "child")
+	check(2*l+1, "grandchild")
+	check(2*l+2, "grandchild")
+	check(2*r+1, "grandchild")
+	check(2*r+2, "grandchild")
+
+	if l < n {
+		verifyFull(t, s, l)
+	}
+	if r < n {
+		verifyFull(t, s, r)
+	}
+}
+
+// TestFixRemoveInvariant stress-tests Fix and Remove at arbitrary interior
+// indices, checking the full min-max invariant (not just against immediate
+// children, which verify does) after every operation. This is the shape
+// that catches a sifted element getting stranded partway down the heap when
+// a multi-level, cross-dimension descent's intermediate-parent fixup swap
+// fires: see verifyFull, and verifyMinMax in the v1 package's bounded_test.go.
+func TestFixRemoveInvariant(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	const n = 200
+
+	s := newIntHeap()
+	s.S = make([]int, n)
+	for i := range s.S {
+		s.S[i] = rng.Intn(n)
+	}
+	h := s.Heap()
+	Init(h)
+
+	for i := 0; i < 20*n; i++ {
+		if h.Len() == 0 {
+			Push(h, rng.Intn(n))
+		}
+		if rng.Intn(4) == 0 {
+			Remove(h, rng.Intn(h.Len()))
+		} else {
+			idx := rng.Intn(h.Len())
+			s.S[idx] = rng.Intn(n)
+			Fix(h, idx)
+		}
+		verifyFull(t, s, 0)
+	}
+}
+
+func BenchmarkDup(b *testing.B) {
+	const n = 10000
+	h := newIntHeap().Heap()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < n; j++ {
+			Push(h, 0) // all elements are the same
+		}
+		for h.Len() > 0 {
+			Pop(h)
+		}
+	}
+}
+
+func BenchmarkOrdered(b *testing.B) {
+	const n = 1000
+	h := newIntHeap().Heap()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < n; j++ {
+			Push(h, j)
+		}
+		for h.Len() > 0 {
+			Pop(h)
+		}
+	}
+}
+
+func BenchmarkRandom(b *testing.B) {
+	rng := rand.New(rand.NewSource(0))
+	const n = 1000
+	h := newIntHeap().Heap()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < n; j++ {
+			if rng.Intn(10) == 1 && h.Len() > 0 {
+				Pop(h)
+			} else {
+				Push(h, rng.Intn(n))
+			}
+		}
+		for h.Len() > 0 {
+			Pop(h)
+		}
+	}
+}
+
+func BenchmarkOrderedPushOnly(b *testing.B) {
+	const n = 1000
+	for i := 0; i < b.N; i++ {
+		h := newIntHeap().Heap()
+		for j := 0; j < n; j++ {
+			Push(h, j)
+		}
+	}
+}